@@ -37,20 +37,9 @@ func (z *ZeroConfAcceptor) RemoveAcceptor(id uint64) {
 func (z *ZeroConfAcceptor) Accept(
 	req *ChannelAcceptRequest) *ChannelAcceptResponse {
 
-	// Alias for less verbosity.
-	channelType := req.OpenChanMsg.ChannelType
-
-	// Check if the channel type sets the zero-conf bit.
-	var zeroConfSet bool
-
-	if channelType != nil {
-		channelFeatures := lnwire.RawFeatureVector(*channelType)
-		zeroConfSet = channelFeatures.IsSet(lnwire.ZeroConfRequired)
-	}
-
 	// If there are no acceptors and the counter-party is requesting a zero
 	// conf channel, reject the attempt.
-	if z.chainedAcceptor.numAcceptors() == 0 && zeroConfSet {
+	if z.chainedAcceptor.numAcceptors() == 0 && requestsZeroConf(req) {
 		// Deny the channel open request.
 		rejectChannel := NewChannelAcceptResponse(
 			false, nil, nil, 0, 0, 0, 0, 0, 0, false,
@@ -63,6 +52,20 @@ func (z *ZeroConfAcceptor) Accept(
 	return z.chainedAcceptor.Accept(req)
 }
 
+// requestsZeroConf returns true if the channel type of the open request sets
+// the zero-conf bit.
+func requestsZeroConf(req *ChannelAcceptRequest) bool {
+	// Alias for less verbosity.
+	channelType := req.OpenChanMsg.ChannelType
+
+	if channelType == nil {
+		return false
+	}
+
+	channelFeatures := lnwire.RawFeatureVector(*channelType)
+	return channelFeatures.IsSet(lnwire.ZeroConfRequired)
+}
+
 // A compile-time constraint to ensure ZeroConfAcceptor implements the
 // MultiplexAcceptor interface.
 var _ MultiplexAcceptor = (*ZeroConfAcceptor)(nil)
@@ -0,0 +1,50 @@
+package chanacceptor
+
+import "github.com/lightningnetwork/lnd/routing/route"
+
+// ZeroConfAllowlist is a ChannelAcceptor that allows zero-conf channel opens
+// from a static set of trusted peers. It never rejects a channel itself: for
+// peers that aren't in the set, or for requests that don't ask for a zero-conf
+// channel, it accepts without enabling zero-conf so that the decision is left
+// to the other acceptors.
+//
+// NOTE: Since a zero-conf channel must have a min depth of zero, the channel
+// is rejected if another acceptor, such as an RPC acceptor, sets a non-zero
+// MinAcceptDepth for an allowlisted peer requesting a zero-conf channel.
+type ZeroConfAllowlist struct {
+	peers map[route.Vertex]struct{}
+}
+
+// NewZeroConfAllowlist initializes a ZeroConfAllowlist for the given peers.
+func NewZeroConfAllowlist(peers []route.Vertex) *ZeroConfAllowlist {
+	allowlist := &ZeroConfAllowlist{
+		peers: make(map[route.Vertex]struct{}, len(peers)),
+	}
+	for _, peer := range peers {
+		allowlist.peers[peer] = struct{}{}
+	}
+
+	return allowlist
+}
+
+// Accept accepts the channel open request, setting ZeroConf if the request
+// sets the zero-conf channel type bit and the requesting node is in the
+// allowlist.
+//
+// NOTE: Part of the ChannelAcceptor interface.
+func (z *ZeroConfAllowlist) Accept(
+	req *ChannelAcceptRequest) *ChannelAcceptResponse {
+
+	var allowed bool
+	if requestsZeroConf(req) {
+		_, allowed = z.peers[route.NewVertex(req.Node)]
+	}
+
+	return NewChannelAcceptResponse(
+		true, nil, nil, 0, 0, 0, 0, 0, 0, allowed,
+	)
+}
+
+// A compile-time constraint to ensure ZeroConfAllowlist implements the
+// ChannelAcceptor interface.
+var _ ChannelAcceptor = (*ZeroConfAllowlist)(nil)
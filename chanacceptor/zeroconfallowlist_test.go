@@ -0,0 +1,185 @@
+package chanacceptor
+
+import (
+	"testing"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/lightningnetwork/lnd/lnwire"
+	"github.com/lightningnetwork/lnd/routing/route"
+	"github.com/stretchr/testify/require"
+)
+
+// TestZeroConfAllowlist verifies that the ZeroConfAllowlist only enables
+// zero-conf for allowlisted peers requesting a zero-conf channel, and never
+// rejects a channel itself.
+func TestZeroConfAllowlist(t *testing.T) {
+	t.Parallel()
+
+	trustedKey, err := btcec.NewPrivateKey()
+	require.NoError(t, err)
+	otherKey, err := btcec.NewPrivateKey()
+	require.NoError(t, err)
+
+	allowlist := NewZeroConfAllowlist([]route.Vertex{
+		route.NewVertex(trustedKey.PubKey()),
+	})
+
+	zeroConfType := new(lnwire.ChannelType)
+	*zeroConfType = lnwire.ChannelType(*lnwire.NewRawFeatureVector(
+		lnwire.ZeroConfRequired,
+	))
+
+	tests := []struct {
+		name        string
+		node        *btcec.PublicKey
+		channelType *lnwire.ChannelType
+		zeroConf    bool
+	}{
+		{
+			name:        "trusted peer zero-conf",
+			node:        trustedKey.PubKey(),
+			channelType: zeroConfType,
+			zeroConf:    true,
+		},
+		{
+			name:     "trusted peer regular",
+			node:     trustedKey.PubKey(),
+			zeroConf: false,
+		},
+		{
+			name:        "other peer zero-conf",
+			node:        otherKey.PubKey(),
+			channelType: zeroConfType,
+			zeroConf:    false,
+		},
+		{
+			name:     "other peer regular",
+			node:     otherKey.PubKey(),
+			zeroConf: false,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			req := &ChannelAcceptRequest{
+				Node: test.node,
+				OpenChanMsg: &lnwire.OpenChannel{
+					ChannelType: test.channelType,
+				},
+			}
+
+			resp := allowlist.Accept(req)
+			require.False(t, resp.RejectChannel())
+			require.Equal(t, test.zeroConf, resp.ZeroConf)
+		})
+	}
+}
+
+// minDepthAcceptor is a ChannelAcceptor that accepts every channel with the
+// given min depth.
+type minDepthAcceptor struct {
+	minDepth uint16
+}
+
+func (m *minDepthAcceptor) Accept(
+	req *ChannelAcceptRequest) *ChannelAcceptResponse {
+
+	return NewChannelAcceptResponse(
+		true, nil, nil, 0, 0, m.minDepth, 0, 0, 0, false,
+	)
+}
+
+// TestZeroConfAllowlistAcceptor verifies that a ZeroConfAcceptor wrapping the
+// ZeroConfAllowlist only enables zero-conf for allowlisted peers, and that an
+// allowlisted peer is rejected if another acceptor sets a non-zero min depth.
+func TestZeroConfAllowlistAcceptor(t *testing.T) {
+	t.Parallel()
+
+	trustedKey, err := btcec.NewPrivateKey()
+	require.NoError(t, err)
+	otherKey, err := btcec.NewPrivateKey()
+	require.NoError(t, err)
+
+	zeroConfType := new(lnwire.ChannelType)
+	*zeroConfType = lnwire.ChannelType(*lnwire.NewRawFeatureVector(
+		lnwire.ZeroConfRequired,
+	))
+
+	tests := []struct {
+		name      string
+		node      *btcec.PublicKey
+		acceptor  ChannelAcceptor
+		rejected  bool
+		zeroConf  bool
+		rejectErr error
+	}{
+		{
+			name:     "trusted peer",
+			node:     trustedKey.PubKey(),
+			zeroConf: true,
+		},
+		{
+			// The ZeroConfAcceptor doesn't reject the channel
+			// since it has a sub-acceptor, but zero-conf isn't
+			// enabled so the funding manager will reject it.
+			name:     "untrusted peer",
+			node:     otherKey.PubKey(),
+			zeroConf: false,
+		},
+		{
+			name:     "trusted peer zero min depth",
+			node:     trustedKey.PubKey(),
+			acceptor: &minDepthAcceptor{},
+			zeroConf: true,
+		},
+		{
+			name:      "trusted peer non-zero min depth",
+			node:      trustedKey.PubKey(),
+			acceptor:  &minDepthAcceptor{minDepth: 3},
+			rejected:  true,
+			rejectErr: errChannelRejected,
+		},
+		{
+			name:     "untrusted peer non-zero min depth",
+			node:     otherKey.PubKey(),
+			acceptor: &minDepthAcceptor{minDepth: 3},
+			zeroConf: false,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			zeroAcceptor := NewZeroConfAcceptor()
+			zeroAcceptor.AddAcceptor(NewZeroConfAllowlist(
+				[]route.Vertex{
+					route.NewVertex(trustedKey.PubKey()),
+				},
+			))
+			if test.acceptor != nil {
+				zeroAcceptor.AddAcceptor(test.acceptor)
+			}
+
+			req := &ChannelAcceptRequest{
+				Node: test.node,
+				OpenChanMsg: &lnwire.OpenChannel{
+					ChannelType: zeroConfType,
+				},
+			}
+
+			resp := zeroAcceptor.Accept(req)
+			require.Equal(t, test.rejected, resp.RejectChannel())
+			require.Equal(t, test.zeroConf, resp.ZeroConf)
+			require.Equal(
+				t, test.rejectErr, resp.ChanAcceptError.error,
+			)
+		})
+	}
+}
@@ -41,6 +41,7 @@ import (
 	"github.com/lightningnetwork/lnd/lnwallet"
 	"github.com/lightningnetwork/lnd/lnwire"
 	"github.com/lightningnetwork/lnd/routing"
+	"github.com/lightningnetwork/lnd/routing/route"
 	"github.com/lightningnetwork/lnd/signal"
 	"github.com/lightningnetwork/lnd/sweep"
 	"github.com/lightningnetwork/lnd/tor"
@@ -302,6 +303,7 @@ type Config struct {
 	DisableListen     bool          `long:"nolisten" description:"Disable listening for incoming peer connections"`
 	DisableRest       bool          `long:"norest" description:"Disable REST API"`
 	DisableRestTLS    bool          `long:"no-rest-tls" description:"Disable TLS for REST connections"`
+	RPCReflection     bool          `long:"rpc-reflection" description:"Register the gRPC server reflection service so tools like grpcurl can discover all RPC services, including sub-servers, without the proto files. Only the v1alpha reflection API (grpc.reflection.v1alpha.ServerReflection) is served. Calls require a macaroon with the info:read permission."`
 	WSPingInterval    time.Duration `long:"ws-ping-interval" description:"The ping interval for REST based WebSocket connections, set to 0 to disable sending ping messages from the server side"`
 	WSPongWait        time.Duration `long:"ws-pong-wait" description:"The time we wait for a pong response message on REST based WebSocket connections before the connection is closed as inactive"`
 	NAT               bool          `long:"nat" description:"Toggle NAT traversal support (using either UPnP or NAT-PMP) to automatically advertise your external IP address to the network -- NOTE this does not support devices behind multiple NATs"`
@@ -383,6 +385,11 @@ type Config struct {
 
 	RejectHTLC bool `long:"rejecthtlc" description:"If true, lnd will not forward any HTLCs that are meant as onward payments. This option will still allow lnd to send HTLCs and receive HTLCs but lnd won't be used as a hop."`
 
+	ZeroConfPeersRaw []string `long:"zero-conf-peer" description:"A peer that is trusted to open zero-conf channels to us without an external channel acceptor. The value should be a hex-encoded pubkey, the flag can be specified multiple times to add multiple peers. Requires protocol.zero-conf to be set. Zero-conf channels from these peers are rejected if another channel acceptor sets a non-zero min accept depth."`
+
+	// ZeroConfPeers is the parsed set of ZeroConfPeersRaw.
+	ZeroConfPeers []route.Vertex
+
 	// RequireInterceptor determines whether the HTLC interceptor is
 	// registered regardless of whether the RPC is called or not.
 	RequireInterceptor bool `long:"requireinterceptor" description:"Whether to always intercept HTLCs, even if no stream is attached"`
@@ -1645,6 +1652,21 @@ func ValidateConfig(cfg Config, interceptor signal.Interceptor, fileParser,
 		return nil, mkErr("error parsing gossip syncer: %v", err)
 	}
 
+	// Trusting peers with zero-conf channels only makes sense if we
+	// signal the zero-conf feature bit in the first place.
+	if len(cfg.ZeroConfPeersRaw) > 0 && !cfg.ProtocolOptions.ZeroConf() {
+		return nil, mkErr("zero-conf-peer requires " +
+			"protocol.zero-conf to be set")
+	}
+	for _, pubKeyStr := range cfg.ZeroConfPeersRaw {
+		vertex, err := route.NewVertexFromStr(pubKeyStr)
+		if err != nil {
+			return nil, mkErr("invalid zero-conf-peer %v: %v",
+				pubKeyStr, err)
+		}
+		cfg.ZeroConfPeers = append(cfg.ZeroConfPeers, vertex)
+	}
+
 	// Log a warning if our expiry delta is not greater than our incoming
 	// broadcast delta. We do not fail here because this value may be set
 	// to zero to intentionally keep lnd's behavior unchanged from when we
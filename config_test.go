@@ -0,0 +1,83 @@
+package lnd
+
+import (
+	"testing"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/jessevdk/go-flags"
+	"github.com/lightningnetwork/lnd/lncfg"
+	"github.com/lightningnetwork/lnd/routing/route"
+	"github.com/lightningnetwork/lnd/signal"
+	"github.com/stretchr/testify/require"
+)
+
+// TestValidateConfigZeroConfPeers tests that zero-conf peers are only
+// accepted if they are valid pubkeys and the zero-conf feature bit is set.
+func TestValidateConfigZeroConfPeers(t *testing.T) {
+	privKey, err := btcec.NewPrivateKey()
+	require.NoError(t, err)
+
+	peer := route.NewVertex(privKey.PubKey())
+
+	testCases := []struct {
+		name     string
+		zeroConf bool
+		peers    []string
+		expected []route.Vertex
+		err      string
+	}{
+		{
+			name:     "no peers",
+			zeroConf: false,
+		},
+		{
+			name:     "valid peer",
+			zeroConf: true,
+			peers:    []string{peer.String()},
+			expected: []route.Vertex{peer},
+		},
+		{
+			name:     "invalid pubkey",
+			zeroConf: true,
+			peers:    []string{"not-a-pubkey"},
+			err:      "invalid zero-conf-peer not-a-pubkey",
+		},
+		{
+			name:     "zero-conf not set",
+			zeroConf: false,
+			peers:    []string{peer.String()},
+			err: "zero-conf-peer requires protocol.zero-conf " +
+				"to be set",
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+
+		t.Run(testCase.name, func(t *testing.T) {
+			cfg := DefaultConfig()
+			cfg.LndDir = t.TempDir()
+			cfg.Bitcoin.Active = true
+			cfg.Bitcoin.SimNet = true
+			cfg.Bitcoin.Node = "nochainbackend"
+			cfg.ProtocolOptions = &lncfg.ProtocolOptions{
+				OptionScidAlias: testCase.zeroConf,
+				OptionZeroConf:  testCase.zeroConf,
+			}
+			cfg.ZeroConfPeersRaw = testCase.peers
+
+			parser := flags.NewParser(&cfg, flags.Default)
+			validCfg, err := ValidateConfig(
+				cfg, signal.Interceptor{}, parser, parser,
+			)
+			if testCase.err != "" {
+				require.ErrorContains(t, err, testCase.err)
+				return
+			}
+
+			require.NoError(t, err)
+			zeroConfPeers := validCfg.ZeroConfPeers
+			require.Equal(t, testCase.expected, zeroConfPeers)
+		})
+	}
+}
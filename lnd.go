@@ -508,6 +508,14 @@ func Main(cfg *Config, lisCfg ListenerCfg, implCfg *ImplementationCfg,
 		multiAcceptor = chanacceptor.NewChainedAcceptor()
 	}
 
+	// If any peers are trusted with zero-conf channels, add an acceptor
+	// that allows them to open those without an RPC acceptor attached.
+	if len(cfg.ZeroConfPeers) > 0 {
+		multiAcceptor.AddAcceptor(
+			chanacceptor.NewZeroConfAllowlist(cfg.ZeroConfPeers),
+		)
+	}
+
 	// Set up the core server which will listen for incoming peer
 	// connections.
 	server, err := newServer(
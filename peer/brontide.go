@@ -364,6 +364,11 @@ type Brontide struct {
 	started    int32
 	disconnect int32
 
+	// disconnectReason is the reason passed to the first call to
+	// Disconnect. It is written before the quit channel is closed and must
+	// only be read once quit has been closed.
+	disconnectReason error
+
 	// MUST be used atomically.
 	bytesReceived uint64
 	bytesSent     uint64
@@ -456,6 +461,14 @@ type Brontide struct {
 	// the connection handshake.
 	remoteFeatures *lnwire.FeatureVector
 
+	// advertisedFeatures holds the same feature vector as remoteFeatures
+	// once it has been received. Unlike remoteFeatures, it can be read
+	// while Start is still running, e.g. when the peer is disconnected
+	// during the init message exchange.
+	//
+	// MUST be used atomically.
+	advertisedFeatures atomic.Value
+
 	// resentChanSyncMsg is a set that keeps track of which channels we
 	// have re-sent channel reestablishment messages for. This is done to
 	// avoid getting into loop where both peers will respond to the other
@@ -1071,6 +1084,8 @@ func (p *Brontide) Disconnect(reason error) {
 		return
 	}
 
+	p.disconnectReason = reason
+
 	err := fmt.Errorf("disconnecting %s, reason: %v", p, reason)
 	p.storeError(err)
 
@@ -1082,6 +1097,17 @@ func (p *Brontide) Disconnect(reason error) {
 	close(p.quit)
 }
 
+// DisconnectReason returns the reason the peer was disconnected with. It
+// returns nil if the peer has not been disconnected yet.
+func (p *Brontide) DisconnectReason() error {
+	select {
+	case <-p.quit:
+		return p.disconnectReason
+	default:
+		return nil
+	}
+}
+
 // String returns the string representation of this peer.
 func (p *Brontide) String() string {
 	return fmt.Sprintf("%x@%s", p.cfg.PubKeyBytes, p.cfg.Conn.RemoteAddr())
@@ -3315,6 +3341,7 @@ func (p *Brontide) handleInitMsg(msg *lnwire.Init) error {
 	p.remoteFeatures = lnwire.NewFeatureVector(
 		msg.Features, lnwire.Features,
 	)
+	p.advertisedFeatures.Store(p.remoteFeatures)
 
 	// Now that we have their features loaded, we'll ensure that they
 	// didn't set any required bits that we don't know of.
@@ -3358,6 +3385,18 @@ func (p *Brontide) RemoteFeatures() *lnwire.FeatureVector {
 	return p.remoteFeatures
 }
 
+// AdvertisedFeatures returns the feature vector the peer advertised in its
+// init message, or nil if it hasn't been received. As opposed to
+// RemoteFeatures, it is safe to call while the peer is still starting up.
+func (p *Brontide) AdvertisedFeatures() *lnwire.FeatureVector {
+	features, ok := p.advertisedFeatures.Load().(*lnwire.FeatureVector)
+	if !ok {
+		return nil
+	}
+
+	return features
+}
+
 // hasNegotiatedScidAlias returns true if we've negotiated the
 // option-scid-alias feature bit with the peer.
 func (p *Brontide) hasNegotiatedScidAlias() bool {
@@ -2,6 +2,7 @@ package peer
 
 import (
 	"bytes"
+	"errors"
 	"testing"
 	"time"
 
@@ -1118,3 +1119,141 @@ func TestPeerCustomMessage(t *testing.T) {
 	require.Equal(t, remoteKey, receivedCustom.peer)
 	require.Equal(t, receivedCustomMsg, &receivedCustom.msg)
 }
+
+// TestDisconnectReason tests that the reason passed to the first call to
+// Disconnect is reported, and that nothing is reported before that.
+func TestDisconnectReason(t *testing.T) {
+	t.Parallel()
+
+	peerKey, err := btcec.NewPrivateKey()
+	require.NoError(t, err)
+
+	alicePeer := NewBrontide(Config{
+		Addr: &lnwire.NetAddress{
+			IdentityKey: peerKey.PubKey(),
+		},
+		Conn: newMockConn(t, 0),
+	})
+
+	// Before the peer is disconnected, no reason should be reported.
+	require.NoError(t, alicePeer.DisconnectReason())
+
+	// Only the first reason should stick, since subsequent calls to
+	// Disconnect are no-ops.
+	firstReason := errors.New("first reason")
+	alicePeer.Disconnect(firstReason)
+	alicePeer.Disconnect(errors.New("second reason"))
+
+	require.Equal(t, firstReason, alicePeer.DisconnectReason())
+}
+
+// TestDisconnectDuringStart tests that the features advertised by the peer
+// can be read once WaitForDisconnect returns, even if the peer was
+// disconnected while it was still exchanging init messages.
+func TestDisconnectDuringStart(t *testing.T) {
+	t.Parallel()
+
+	dbAlice, err := channeldb.Open(t.TempDir())
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, dbAlice.Close())
+	})
+
+	aliceKey, err := btcec.NewPrivateKey()
+	require.NoError(t, err)
+
+	writePool := pool.NewWrite(
+		pool.NewWriteBuffer(
+			pool.DefaultWriteBufferGCInterval,
+			pool.DefaultWriteBufferExpiryInterval,
+		), 1, timeout,
+	)
+	require.NoError(t, writePool.Start())
+
+	readPool := pool.NewRead(
+		pool.NewReadBuffer(
+			pool.DefaultReadBufferGCInterval,
+			pool.DefaultReadBufferExpiryInterval,
+		), 1, timeout,
+	)
+	require.NoError(t, readPool.Start())
+
+	channelNotifier := channelnotifier.New(dbAlice.ChannelStateDB())
+	require.NoError(t, channelNotifier.Start())
+	t.Cleanup(func() {
+		require.NoError(t, channelNotifier.Stop())
+	})
+
+	mockConn := newMockConn(t, 1)
+	alicePeer := NewBrontide(Config{
+		PubKeyBytes: [33]byte{8},
+		ChannelDB:   dbAlice.ChannelStateDB(),
+		Addr: &lnwire.NetAddress{
+			IdentityKey: aliceKey.PubKey(),
+		},
+		PrunePersistentPeerConnection: func([33]byte) {},
+		Features:                      lnwire.EmptyFeatureVector(),
+		LegacyFeatures:                lnwire.EmptyFeatureVector(),
+		WritePool:                     writePool,
+		ReadPool:                      readPool,
+		Conn:                          mockConn,
+		ChainNotifier: &mock.ChainNotifier{
+			SpendChan: make(chan *chainntnfs.SpendDetail),
+			EpochChan: make(chan *chainntnfs.BlockEpoch),
+			ConfChan:  make(chan *chainntnfs.TxConfirmation),
+		},
+		PongBuf:         make([]byte, lnwire.MaxPongBytes),
+		ChannelNotifier: channelNotifier,
+	})
+
+	startErr := make(chan error, 1)
+	go func() {
+		startErr <- alicePeer.Start()
+	}()
+
+	// Wait for our init message, then disconnect the peer before it
+	// replies, like the server does on a handshake timeout.
+	<-mockConn.writtenMessages
+	alicePeer.Disconnect(errors.New("disconnected during start"))
+
+	// Let the peer reply with its init message, which Start will still
+	// process.
+	initReplyMsg := lnwire.NewInitMessage(
+		lnwire.NewRawFeatureVector(lnwire.DataLossProtectRequired),
+		lnwire.NewRawFeatureVector(),
+	)
+	var b bytes.Buffer
+	_, err = lnwire.WriteMessage(&b, initReplyMsg, 0)
+	require.NoError(t, err)
+	mockConn.readMessages <- b.Bytes()
+
+	// Meanwhile, read the features like the server does once the peer is
+	// disconnected. This must not race with Start.
+	deadline := time.After(timeout)
+	for {
+		features := alicePeer.AdvertisedFeatures()
+		if features != nil {
+			require.True(t, features.HasFeature(
+				lnwire.DataLossProtectRequired,
+			))
+		}
+
+		select {
+		case <-startErr:
+			// Once Start has returned, the features must be
+			// available.
+			features = alicePeer.AdvertisedFeatures()
+			require.NotNil(t, features)
+			require.True(t, features.HasFeature(
+				lnwire.DataLossProtectRequired,
+			))
+
+			return
+
+		case <-time.After(time.Millisecond):
+
+		case <-deadline:
+			t.Fatalf("peer did not finish starting")
+		}
+	}
+}
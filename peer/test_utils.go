@@ -557,3 +557,8 @@ func (m *mockMessageConn) RemoteAddr() net.Addr {
 func (m *mockMessageConn) LocalAddr() net.Addr {
 	return nil
 }
+
+// Close mocks closing the connection.
+func (m *mockMessageConn) Close() error {
+	return nil
+}
@@ -1,8 +1,11 @@
 package peernotifier
 
 import (
+	"net"
 	"sync"
+	"time"
 
+	"github.com/lightningnetwork/lnd/lnwire"
 	"github.com/lightningnetwork/lnd/subscribe"
 )
 
@@ -20,12 +23,56 @@ type PeerNotifier struct {
 type PeerOnlineEvent struct {
 	// PubKey is the peer's compressed public key.
 	PubKey [33]byte
+
+	// Inbound is true if the connection was initiated by the peer.
+	Inbound bool
+
+	// Address is the network address the peer is connected on.
+	Address net.Addr
 }
 
 // PeerOfflineEvent represents a new event where a peer goes offline.
 type PeerOfflineEvent struct {
 	// PubKey is the peer's compressed public key.
 	PubKey [33]byte
+
+	// Inbound is true if the connection was initiated by the peer.
+	Inbound bool
+
+	// Address is the network address the peer was connected on.
+	Address net.Addr
+
+	// Features is the feature vector the peer advertised in its init
+	// message. It is nil if the connection went down before the init
+	// messages were exchanged.
+	Features *lnwire.FeatureVector
+
+	// Reason is the error the connection was torn down with, if known.
+	Reason error
+}
+
+// PeerAddressUpdatedEvent represents a new event where the set of addresses
+// we use to reach a persistent peer changed because the peer announced new
+// addresses over gossip.
+type PeerAddressUpdatedEvent struct {
+	// PubKey is the peer's compressed public key.
+	PubKey [33]byte
+
+	// Addresses is the new set of addresses we'll use to reach the peer.
+	Addresses []net.Addr
+}
+
+// PeerReconnectScheduledEvent represents a new event where a reconnection
+// attempt to a persistent peer has been scheduled after it went offline. It is
+// only sent for the first attempt after a disconnect: if that attempt fails,
+// the connection manager retries on its own backoff without further events.
+// Connection attempts made on startup aren't reported either.
+type PeerReconnectScheduledEvent struct {
+	// PubKey is the peer's compressed public key.
+	PubKey [33]byte
+
+	// Backoff is the time we'll wait before attempting to reconnect.
+	Backoff time.Duration
 }
 
 // New creates a new peer notifier which notifies clients of peer online
@@ -66,10 +113,8 @@ func (p *PeerNotifier) SubscribePeerEvents() (*subscribe.Client, error) {
 
 // NotifyPeerOnline sends a peer online event to all clients subscribed to the
 // peer notifier.
-func (p *PeerNotifier) NotifyPeerOnline(pubKey [33]byte) {
-	event := PeerOnlineEvent{PubKey: pubKey}
-
-	log.Debugf("PeerNotifier notifying peer: %x online", pubKey)
+func (p *PeerNotifier) NotifyPeerOnline(event PeerOnlineEvent) {
+	log.Debugf("PeerNotifier notifying peer: %x online", event.PubKey)
 
 	if err := p.ntfnServer.SendUpdate(event); err != nil {
 		log.Warnf("Unable to send peer online update: %v", err)
@@ -78,12 +123,35 @@ func (p *PeerNotifier) NotifyPeerOnline(pubKey [33]byte) {
 
 // NotifyPeerOffline sends a peer offline event to all the clients subscribed
 // to the peer notifier.
-func (p *PeerNotifier) NotifyPeerOffline(pubKey [33]byte) {
-	event := PeerOfflineEvent{PubKey: pubKey}
-
-	log.Debugf("PeerNotifier notifying peer: %x offline", pubKey)
+func (p *PeerNotifier) NotifyPeerOffline(event PeerOfflineEvent) {
+	log.Debugf("PeerNotifier notifying peer: %x offline, reason: %v",
+		event.PubKey, event.Reason)
 
 	if err := p.ntfnServer.SendUpdate(event); err != nil {
 		log.Warnf("Unable to send peer offline update: %v", err)
 	}
 }
+
+// NotifyPeerAddressUpdated sends a peer address updated event to all the
+// clients subscribed to the peer notifier.
+func (p *PeerNotifier) NotifyPeerAddressUpdated(event PeerAddressUpdatedEvent) {
+	log.Debugf("PeerNotifier notifying peer: %x addresses updated: %v",
+		event.PubKey, event.Addresses)
+
+	if err := p.ntfnServer.SendUpdate(event); err != nil {
+		log.Warnf("Unable to send peer address update: %v", err)
+	}
+}
+
+// NotifyPeerReconnectScheduled sends a peer reconnect scheduled event to all
+// the clients subscribed to the peer notifier.
+func (p *PeerNotifier) NotifyPeerReconnectScheduled(
+	event PeerReconnectScheduledEvent) {
+
+	log.Debugf("PeerNotifier notifying peer: %x reconnect in %v",
+		event.PubKey, event.Backoff)
+
+	if err := p.ntfnServer.SendUpdate(event); err != nil {
+		log.Warnf("Unable to send peer reconnect update: %v", err)
+	}
+}
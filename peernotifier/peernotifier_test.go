@@ -0,0 +1,67 @@
+package peernotifier
+
+import (
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// TestPeerNotifier tests that every kind of peer event is delivered to
+// subscribers unchanged.
+func TestPeerNotifier(t *testing.T) {
+	t.Parallel()
+
+	notifier := New()
+	require.NoError(t, notifier.Start())
+	t.Cleanup(func() {
+		require.NoError(t, notifier.Stop())
+	})
+
+	sub, err := notifier.SubscribePeerEvents()
+	require.NoError(t, err)
+	defer sub.Cancel()
+
+	pubKey := [33]byte{0x02, 0x01}
+	addr := &net.TCPAddr{IP: net.ParseIP("10.0.0.1"), Port: 9735}
+
+	onlineEvent := PeerOnlineEvent{
+		PubKey:  pubKey,
+		Inbound: true,
+		Address: addr,
+	}
+	offlineEvent := PeerOfflineEvent{
+		PubKey:  pubKey,
+		Inbound: true,
+		Address: addr,
+		Reason:  errors.New("disconnected"),
+	}
+	addrEvent := PeerAddressUpdatedEvent{
+		PubKey:    pubKey,
+		Addresses: []net.Addr{addr},
+	}
+	reconnectEvent := PeerReconnectScheduledEvent{
+		PubKey:  pubKey,
+		Backoff: time.Second,
+	}
+
+	notifier.NotifyPeerOnline(onlineEvent)
+	notifier.NotifyPeerOffline(offlineEvent)
+	notifier.NotifyPeerAddressUpdated(addrEvent)
+	notifier.NotifyPeerReconnectScheduled(reconnectEvent)
+
+	expected := []interface{}{
+		onlineEvent, offlineEvent, addrEvent, reconnectEvent,
+	}
+	for _, event := range expected {
+		select {
+		case update := <-sub.Updates():
+			require.Equal(t, event, update)
+
+		case <-time.After(time.Second):
+			t.Fatalf("expected event %T not received", event)
+		}
+	}
+}
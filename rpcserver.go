@@ -72,11 +72,13 @@ import (
 	"github.com/lightningnetwork/lnd/signal"
 	"github.com/lightningnetwork/lnd/sweep"
 	"github.com/lightningnetwork/lnd/tlv"
+	"github.com/lightningnetwork/lnd/tor"
 	"github.com/lightningnetwork/lnd/watchtower"
 	"github.com/lightningnetwork/lnd/zpay32"
 	"github.com/tv42/zbase32"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/reflection"
 	"google.golang.org/grpc/status"
 	"gopkg.in/macaroon-bakery.v2/bakery"
 )
@@ -212,6 +214,11 @@ var (
 		"remove --no-macaroons flag to enable")
 )
 
+// reflectionMethod is the full method name of the v1alpha gRPC server
+// reflection stream, registered when --rpc-reflection is set.
+const reflectionMethod = "/grpc.reflection.v1alpha.ServerReflection/" +
+	"ServerReflectionInfo"
+
 // stringInSlice returns true if a string is contained in the given slice.
 func stringInSlice(a string, slice []string) bool {
 	for _, b := range slice {
@@ -830,6 +837,10 @@ func (r *rpcServer) addDeps(s *server, macService *macaroons.Service,
 		}
 	}
 
+	if err := r.addReflectionPermission(); err != nil {
+		return err
+	}
+
 	// External subserver possibly need to register their own permissions
 	// and macaroon validator.
 	for method, ops := range r.implCfg.ExternalValidator.Permissions() {
@@ -879,6 +890,22 @@ func (r *rpcServer) addDeps(s *server, macService *macaroons.Service,
 	return nil
 }
 
+// addReflectionPermission adds the permission required to call the gRPC
+// server reflection service if it is enabled. As the service reveals the full
+// RPC surface, we gate it behind the same permission as GetInfo.
+func (r *rpcServer) addReflectionPermission() error {
+	if !r.cfg.RPCReflection {
+		return nil
+	}
+
+	return r.interceptorChain.AddPermission(
+		reflectionMethod, []bakery.Op{{
+			Entity: "info",
+			Action: "read",
+		}},
+	)
+}
+
 // RegisterWithGrpcServer registers the rpcServer and any subservers with the
 // root gRPC server.
 func (r *rpcServer) RegisterWithGrpcServer(grpcServer *grpc.Server) error {
@@ -908,6 +935,12 @@ func (r *rpcServer) RegisterWithGrpcServer(grpcServer *grpc.Server) error {
 			"subserver: %v", err)
 	}
 
+	// Finally, expose the reflection service if requested. It serves the
+	// descriptors of every service registered above at call time.
+	if r.cfg.RPCReflection {
+		reflection.Register(grpcServer)
+	}
+
 	return nil
 }
 
@@ -3066,40 +3099,206 @@ func (r *rpcServer) ListPeers(ctx context.Context,
 	return resp, nil
 }
 
+// marshallPeerEvent converts a peer notifier event into its RPC counterpart.
+func marshallPeerEvent(e interface{}) (*lnrpc.PeerEvent, error) {
+	var event *lnrpc.PeerEvent
+
+	switch peerEvent := e.(type) {
+	case peernotifier.PeerOfflineEvent:
+		event = &lnrpc.PeerEvent{
+			PubKey:  hex.EncodeToString(peerEvent.PubKey[:]),
+			Type:    lnrpc.PeerEvent_PEER_OFFLINE,
+			Inbound: peerEvent.Inbound,
+			Features: invoicesrpc.CreateRPCFeatures(
+				peerEvent.Features,
+			),
+		}
+		if peerEvent.Reason != nil {
+			event.DisconnectReason = peerEvent.Reason.Error()
+		}
+		marshallPeerEventAddress(event, peerEvent.Address)
+
+	case peernotifier.PeerOnlineEvent:
+		event = &lnrpc.PeerEvent{
+			PubKey:  hex.EncodeToString(peerEvent.PubKey[:]),
+			Type:    lnrpc.PeerEvent_PEER_ONLINE,
+			Inbound: peerEvent.Inbound,
+		}
+		marshallPeerEventAddress(event, peerEvent.Address)
+
+	case peernotifier.PeerAddressUpdatedEvent:
+		addrs := make([]string, 0, len(peerEvent.Addresses))
+		for _, addr := range peerEvent.Addresses {
+			addrs = append(addrs, addr.String())
+		}
+
+		event = &lnrpc.PeerEvent{
+			PubKey:    hex.EncodeToString(peerEvent.PubKey[:]),
+			Type:      lnrpc.PeerEvent_PEER_ADDRESS_UPDATED,
+			Addresses: addrs,
+		}
+
+	case peernotifier.PeerReconnectScheduledEvent:
+		event = &lnrpc.PeerEvent{
+			PubKey: hex.EncodeToString(peerEvent.PubKey[:]),
+			Type:   lnrpc.PeerEvent_PEER_RECONNECT_SCHEDULED,
+			ReconnectBackoffMs: uint64(
+				peerEvent.Backoff.Milliseconds(),
+			),
+		}
+
+	default:
+		return nil, fmt.Errorf("unexpected peer event: %v", e)
+	}
+
+	return event, nil
+}
+
+// marshallPeerEventAddress sets the address and address type of the given
+// peer event from the peer's network address.
+func marshallPeerEventAddress(event *lnrpc.PeerEvent, addr net.Addr) {
+	if addr == nil {
+		return
+	}
+
+	event.Address = addr.String()
+
+	switch a := addr.(type) {
+	case *net.TCPAddr:
+		if a.IP.To4() != nil {
+			event.AddressType = lnrpc.PeerEvent_IPV4
+		} else {
+			event.AddressType = lnrpc.PeerEvent_IPV6
+		}
+
+	case *tor.OnionAddr:
+		switch len(a.OnionService) {
+		case tor.V2Len:
+			event.AddressType = lnrpc.PeerEvent_TOR_V2
+		case tor.V3Len:
+			event.AddressType = lnrpc.PeerEvent_TOR_V3
+		}
+	}
+}
+
+// peerEventFilter selects the peer events that are sent to a
+// SubscribePeerEvents client.
+type peerEventFilter struct {
+	// pubKeys is the set of hex-encoded peer pubkeys events are sent for.
+	// If empty, events for all peers are sent.
+	pubKeys map[string]struct{}
+
+	// eventTypes is the set of event types that are sent. If empty, all
+	// event types are sent.
+	eventTypes map[lnrpc.PeerEvent_EventType]struct{}
+}
+
+// newPeerEventFilter creates a peerEventFilter from the filter fields of a
+// peer event subscription.
+func newPeerEventFilter(
+	req *lnrpc.PeerEventSubscription) (*peerEventFilter, error) {
+
+	filter := &peerEventFilter{
+		pubKeys: make(map[string]struct{}, len(req.PubKeys)),
+		eventTypes: make(
+			map[lnrpc.PeerEvent_EventType]struct{},
+			len(req.EventTypes),
+		),
+	}
+
+	for _, pubKeyStr := range req.PubKeys {
+		pubKey, err := route.NewVertexFromStr(pubKeyStr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid pub key %v: %v",
+				pubKeyStr, err)
+		}
+
+		filter.pubKeys[pubKey.String()] = struct{}{}
+	}
+
+	for _, eventType := range req.EventTypes {
+		filter.eventTypes[eventType] = struct{}{}
+	}
+
+	return filter, nil
+}
+
+// matches returns true if the given event should be sent to the client.
+func (f *peerEventFilter) matches(event *lnrpc.PeerEvent) bool {
+	if len(f.pubKeys) > 0 {
+		if _, ok := f.pubKeys[event.PubKey]; !ok {
+			return false
+		}
+	}
+
+	if len(f.eventTypes) > 0 {
+		if _, ok := f.eventTypes[event.Type]; !ok {
+			return false
+		}
+	}
+
+	return true
+}
+
 // SubscribePeerEvents returns a uni-directional stream (server -> client)
-// for notifying the client of peer online and offline events.
+// for notifying the client of peer online and offline events, persistent peer
+// address updates and scheduled reconnection attempts.
 func (r *rpcServer) SubscribePeerEvents(req *lnrpc.PeerEventSubscription,
 	eventStream lnrpc.Lightning_SubscribePeerEventsServer) error {
 
+	filter, err := newPeerEventFilter(req)
+	if err != nil {
+		return err
+	}
+
 	peerEventSub, err := r.server.peerNotifier.SubscribePeerEvents()
 	if err != nil {
 		return err
 	}
 	defer peerEventSub.Cancel()
 
+	// If requested, report the peers we're currently connected to before
+	// any live events. As we've already subscribed, no change is missed,
+	// though a peer connecting in the meantime may be reported twice.
+	if req.IncludeCurrentState {
+		for _, p := range r.server.Peers() {
+			event, err := marshallPeerEvent(
+				peernotifier.PeerOnlineEvent{
+					PubKey:  p.PubKey(),
+					Inbound: p.Inbound(),
+					Address: p.Address(),
+				},
+			)
+			if err != nil {
+				return err
+			}
+			event.SyntheticSnapshot = true
+
+			if !filter.matches(event) {
+				continue
+			}
+
+			if err := eventStream.Send(event); err != nil {
+				return err
+			}
+		}
+	}
+
 	for {
 		select {
 		// A new update has been sent by the peer notifier, we'll
 		// marshal it into the form expected by the gRPC client, then
 		// send it off to the client.
 		case e := <-peerEventSub.Updates():
-			var event *lnrpc.PeerEvent
-
-			switch peerEvent := e.(type) {
-			case peernotifier.PeerOfflineEvent:
-				event = &lnrpc.PeerEvent{
-					PubKey: hex.EncodeToString(peerEvent.PubKey[:]),
-					Type:   lnrpc.PeerEvent_PEER_OFFLINE,
-				}
-
-			case peernotifier.PeerOnlineEvent:
-				event = &lnrpc.PeerEvent{
-					PubKey: hex.EncodeToString(peerEvent.PubKey[:]),
-					Type:   lnrpc.PeerEvent_PEER_ONLINE,
-				}
+			event, err := marshallPeerEvent(e)
+			if err != nil {
+				return err
+			}
 
-			default:
-				return fmt.Errorf("unexpected peer event: %v", event)
+			// Skip the event if the client isn't interested in
+			// it.
+			if !filter.matches(event) {
+				continue
 			}
 
 			if err := eventStream.Send(event); err != nil {
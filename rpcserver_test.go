@@ -1,9 +1,26 @@
 package lnd
 
 import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"net"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/btcsuite/btclog"
+	"github.com/lightningnetwork/lnd/lnrpc"
+	"github.com/lightningnetwork/lnd/lnwire"
+	"github.com/lightningnetwork/lnd/peer"
+	"github.com/lightningnetwork/lnd/peernotifier"
+	"github.com/lightningnetwork/lnd/rpcperms"
+	"github.com/lightningnetwork/lnd/tor"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	rpb "google.golang.org/grpc/reflection/grpc_reflection_v1alpha"
+	"gopkg.in/macaroon-bakery.v2/bakery"
 )
 
 func TestGetAllPermissions(t *testing.T) {
@@ -12,3 +29,422 @@ func TestGetAllPermissions(t *testing.T) {
 	// Currently there are there are 16 entity:action pairs in use.
 	assert.Equal(t, len(perms), 16)
 }
+
+// TestPeerEventFilter tests that peer events are filtered by the pubkeys and
+// event types of a subscription.
+func TestPeerEventFilter(t *testing.T) {
+	t.Parallel()
+
+	pubKey1 := "02" + strings.Repeat("11", 32)
+	pubKey2 := "03" + strings.Repeat("22", 32)
+
+	online1 := &lnrpc.PeerEvent{
+		PubKey: pubKey1,
+		Type:   lnrpc.PeerEvent_PEER_ONLINE,
+	}
+	offline1 := &lnrpc.PeerEvent{
+		PubKey: pubKey1,
+		Type:   lnrpc.PeerEvent_PEER_OFFLINE,
+	}
+	online2 := &lnrpc.PeerEvent{
+		PubKey: pubKey2,
+		Type:   lnrpc.PeerEvent_PEER_ONLINE,
+	}
+
+	tests := []struct {
+		name    string
+		req     *lnrpc.PeerEventSubscription
+		matches []bool
+	}{
+		{
+			name:    "no filter",
+			req:     &lnrpc.PeerEventSubscription{},
+			matches: []bool{true, true, true},
+		},
+		{
+			name: "pubkey filter",
+			req: &lnrpc.PeerEventSubscription{
+				PubKeys: []string{strings.ToUpper(pubKey1)},
+			},
+			matches: []bool{true, true, false},
+		},
+		{
+			name: "event type filter",
+			req: &lnrpc.PeerEventSubscription{
+				EventTypes: []lnrpc.PeerEvent_EventType{
+					lnrpc.PeerEvent_PEER_ONLINE,
+				},
+			},
+			matches: []bool{true, false, true},
+		},
+		{
+			name: "pubkey and event type filter",
+			req: &lnrpc.PeerEventSubscription{
+				PubKeys: []string{pubKey1},
+				EventTypes: []lnrpc.PeerEvent_EventType{
+					lnrpc.PeerEvent_PEER_OFFLINE,
+				},
+			},
+			matches: []bool{false, true, false},
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			filter, err := newPeerEventFilter(test.req)
+			require.NoError(t, err)
+
+			events := []*lnrpc.PeerEvent{online1, offline1, online2}
+			for i, event := range events {
+				match := filter.matches(event)
+				require.Equal(t, test.matches[i], match)
+			}
+		})
+	}
+
+	// An invalid pubkey should be rejected.
+	_, err := newPeerEventFilter(&lnrpc.PeerEventSubscription{
+		PubKeys: []string{"not-a-pubkey"},
+	})
+	require.Error(t, err)
+}
+
+// TestMarshallPeerEvent tests that peer notifier events are converted into
+// the expected RPC peer events.
+func TestMarshallPeerEvent(t *testing.T) {
+	t.Parallel()
+
+	var pubKey [33]byte
+	pubKey[0] = 0x02
+	pubKeyStr := "02" + strings.Repeat("00", 32)
+
+	ipv4Addr := &net.TCPAddr{IP: net.ParseIP("10.0.0.1"), Port: 9735}
+	ipv6Addr := &net.TCPAddr{IP: net.ParseIP("2001:db8::1"), Port: 9735}
+	onionAddr := func(length int) *tor.OnionAddr {
+		service := strings.Repeat("a", length-tor.OnionSuffixLen)
+
+		return &tor.OnionAddr{
+			OnionService: service + tor.OnionSuffix,
+			Port:         9735,
+		}
+	}
+	v2Addr := onionAddr(tor.V2Len)
+	v3Addr := onionAddr(tor.V3Len)
+
+	features := lnwire.NewFeatureVector(
+		lnwire.NewRawFeatureVector(lnwire.DataLossProtectRequired),
+		lnwire.Features,
+	)
+	dlpBit := uint32(lnwire.DataLossProtectRequired)
+
+	tests := []struct {
+		name     string
+		event    interface{}
+		expected *lnrpc.PeerEvent
+	}{
+		{
+			name: "online ipv4",
+			event: peernotifier.PeerOnlineEvent{
+				PubKey:  pubKey,
+				Inbound: true,
+				Address: ipv4Addr,
+			},
+			expected: &lnrpc.PeerEvent{
+				PubKey:      pubKeyStr,
+				Type:        lnrpc.PeerEvent_PEER_ONLINE,
+				Inbound:     true,
+				Address:     ipv4Addr.String(),
+				AddressType: lnrpc.PeerEvent_IPV4,
+			},
+		},
+		{
+			name: "online ipv6",
+			event: peernotifier.PeerOnlineEvent{
+				PubKey:  pubKey,
+				Address: ipv6Addr,
+			},
+			expected: &lnrpc.PeerEvent{
+				PubKey:      pubKeyStr,
+				Type:        lnrpc.PeerEvent_PEER_ONLINE,
+				Address:     ipv6Addr.String(),
+				AddressType: lnrpc.PeerEvent_IPV6,
+			},
+		},
+		{
+			name: "online tor v2",
+			event: peernotifier.PeerOnlineEvent{
+				PubKey:  pubKey,
+				Address: v2Addr,
+			},
+			expected: &lnrpc.PeerEvent{
+				PubKey:      pubKeyStr,
+				Type:        lnrpc.PeerEvent_PEER_ONLINE,
+				Address:     v2Addr.String(),
+				AddressType: lnrpc.PeerEvent_TOR_V2,
+			},
+		},
+		{
+			name: "offline tor v3",
+			event: peernotifier.PeerOfflineEvent{
+				PubKey:   pubKey,
+				Inbound:  true,
+				Address:  v3Addr,
+				Features: features,
+				Reason:   errors.New("peer went away"),
+			},
+			expected: &lnrpc.PeerEvent{
+				PubKey:  pubKeyStr,
+				Type:    lnrpc.PeerEvent_PEER_OFFLINE,
+				Inbound: true,
+				Features: map[uint32]*lnrpc.Feature{
+					dlpBit: {
+						Name:       "data-loss-protect",
+						IsRequired: true,
+						IsKnown:    true,
+					},
+				},
+				DisconnectReason: "peer went away",
+				Address:          v3Addr.String(),
+				AddressType:      lnrpc.PeerEvent_TOR_V3,
+			},
+		},
+		{
+			name: "address updated",
+			event: peernotifier.PeerAddressUpdatedEvent{
+				PubKey: pubKey,
+				Addresses: []net.Addr{
+					ipv4Addr, ipv6Addr, v3Addr,
+				},
+			},
+			expected: &lnrpc.PeerEvent{
+				PubKey: pubKeyStr,
+				Type:   lnrpc.PeerEvent_PEER_ADDRESS_UPDATED,
+				Addresses: []string{
+					ipv4Addr.String(), ipv6Addr.String(),
+					v3Addr.String(),
+				},
+			},
+		},
+		{
+			name: "reconnect scheduled",
+			event: peernotifier.PeerReconnectScheduledEvent{
+				PubKey:  pubKey,
+				Backoff: 5 * time.Second,
+			},
+			expected: &lnrpc.PeerEvent{
+				Type: lnrpc.PeerEvent_PEER_RECONNECT_SCHEDULED,
+
+				PubKey:             pubKeyStr,
+				ReconnectBackoffMs: 5000,
+			},
+		},
+		{
+			name: "offline without address",
+			event: peernotifier.PeerOfflineEvent{
+				PubKey: pubKey,
+			},
+			expected: &lnrpc.PeerEvent{
+				PubKey: pubKeyStr,
+				Type:   lnrpc.PeerEvent_PEER_OFFLINE,
+			},
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			event, err := marshallPeerEvent(test.event)
+			require.NoError(t, err)
+			require.Equal(t, test.expected, event)
+		})
+	}
+
+	// Unknown events should result in an error.
+	_, err := marshallPeerEvent(struct{}{})
+	require.Error(t, err)
+}
+
+// peerEventStreamMock is a mock of the SubscribePeerEvents server stream.
+type peerEventStreamMock struct {
+	grpc.ServerStream
+	ctx            context.Context
+	sentFromServer chan *lnrpc.PeerEvent
+}
+
+func (m *peerEventStreamMock) Context() context.Context {
+	return m.ctx
+}
+
+func (m *peerEventStreamMock) Send(event *lnrpc.PeerEvent) error {
+	m.sentFromServer <- event
+	return nil
+}
+
+// TestSubscribePeerEventsSnapshot tests that the currently connected peers
+// are only reported if include_current_state is set, and that the snapshot
+// respects the subscription's filters.
+func TestSubscribePeerEventsSnapshot(t *testing.T) {
+	t.Parallel()
+
+	pubKey1 := [33]byte{0x02, 0x01}
+	pubKey2 := [33]byte{0x02, 0x02}
+	addr := &net.TCPAddr{IP: net.ParseIP("10.0.0.1"), Port: 9735}
+
+	newPeer := func(pubKey [33]byte) *peer.Brontide {
+		return peer.NewBrontide(peer.Config{
+			PubKeyBytes: pubKey,
+			Addr:        &lnwire.NetAddress{Address: addr},
+			Inbound:     true,
+		})
+	}
+
+	notifier := peernotifier.New()
+	require.NoError(t, notifier.Start())
+	t.Cleanup(func() {
+		require.NoError(t, notifier.Stop())
+	})
+
+	r := &rpcServer{
+		server: &server{
+			peersByPub: map[string]*peer.Brontide{
+				string(pubKey1[:]): newPeer(pubKey1),
+				string(pubKey2[:]): newPeer(pubKey2),
+			},
+			peerNotifier: notifier,
+		},
+		quit: make(chan struct{}),
+	}
+
+	tests := []struct {
+		name     string
+		req      *lnrpc.PeerEventSubscription
+		expected []string
+	}{
+		{
+			name:     "no snapshot",
+			req:      &lnrpc.PeerEventSubscription{},
+			expected: nil,
+		},
+		{
+			name: "snapshot",
+			req: &lnrpc.PeerEventSubscription{
+				IncludeCurrentState: true,
+			},
+			expected: []string{
+				hex.EncodeToString(pubKey1[:]),
+				hex.EncodeToString(pubKey2[:]),
+			},
+		},
+		{
+			name: "filtered snapshot",
+			req: &lnrpc.PeerEventSubscription{
+				IncludeCurrentState: true,
+				PubKeys: []string{
+					hex.EncodeToString(pubKey2[:]),
+				},
+			},
+			expected: []string{
+				hex.EncodeToString(pubKey2[:]),
+			},
+		},
+		{
+			name: "snapshot filtered by event type",
+			req: &lnrpc.PeerEventSubscription{
+				IncludeCurrentState: true,
+				EventTypes: []lnrpc.PeerEvent_EventType{
+					lnrpc.PeerEvent_PEER_OFFLINE,
+				},
+			},
+			expected: nil,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx, cancel := context.WithCancel(context.Background())
+			stream := &peerEventStreamMock{
+				ctx:            ctx,
+				sentFromServer: make(chan *lnrpc.PeerEvent, 10),
+			}
+
+			errChan := make(chan error, 1)
+			go func() {
+				err := r.SubscribePeerEvents(test.req, stream)
+				errChan <- err
+			}()
+
+			var pubKeys []string
+			for range test.expected {
+				select {
+				case event := <-stream.sentFromServer:
+					require.Equal(
+						t, lnrpc.PeerEvent_PEER_ONLINE,
+						event.Type,
+					)
+					require.True(t, event.SyntheticSnapshot)
+					require.True(t, event.Inbound)
+					require.Equal(
+						t, addr.String(), event.Address,
+					)
+					pubKeys = append(pubKeys, event.PubKey)
+
+				case <-time.After(time.Second):
+					t.Fatalf("snapshot event not received")
+				}
+			}
+			require.ElementsMatch(t, test.expected, pubKeys)
+
+			// Once the stream is closed, no further events should
+			// have been sent.
+			cancel()
+			require.NoError(t, <-errChan)
+			require.Empty(t, stream.sentFromServer)
+		})
+	}
+}
+
+// TestReflectionPermission tests that the permission for the gRPC server
+// reflection service is only registered if the service is enabled.
+func TestReflectionPermission(t *testing.T) {
+	t.Parallel()
+
+	// Make sure the method we register the permission for is the one
+	// served by the reflection service.
+	require.Equal(
+		t, "/"+rpb.ServerReflection_ServiceDesc.ServiceName+"/"+
+			rpb.ServerReflection_ServiceDesc.Streams[0].StreamName,
+		reflectionMethod,
+	)
+
+	for _, enabled := range []bool{false, true} {
+		r := &rpcServer{
+			cfg: &Config{
+				RPCReflection: enabled,
+			},
+			interceptorChain: rpcperms.NewInterceptorChain(
+				btclog.Disabled, false, nil,
+			),
+		}
+		require.NoError(t, r.addReflectionPermission())
+
+		ops, ok := r.interceptorChain.Permissions()[reflectionMethod]
+		require.Equal(t, enabled, ok)
+		if enabled {
+			require.Equal(t, []bakery.Op{{
+				Entity: "info",
+				Action: "read",
+			}}, ops)
+		}
+	}
+}
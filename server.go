@@ -387,20 +387,35 @@ func (s *server) updatePersistentPeerAddrs() error {
 
 					// Update the stored addresses for this
 					// to peer to reflect the new set.
-					s.persistentPeerAddrs[pubKeyStr] = addrs
+					changed := s.setPersistentPeerAddrs(
+						pubKeyStr, addrs,
+					)
+
+					numConnReqs := len(
+						s.persistentConnReqs[pubKeyStr],
+					)
+
+					s.mu.Unlock()
+
+					// Only let subscribers know about the
+					// new addresses if they differ from
+					// the ones we had before.
+					if changed {
+						s.notifyPeerAddrsUpdated(
+							pubKeyStr,
+							update.Addresses,
+						)
+					}
 
 					// If there are no outstanding
 					// connection requests for this peer
 					// then our work is done since we are
 					// not currently trying to connect to
 					// them.
-					if len(s.persistentConnReqs[pubKeyStr]) == 0 {
-						s.mu.Unlock()
+					if numConnReqs == 0 {
 						continue
 					}
 
-					s.mu.Unlock()
-
 					s.connectToPersistentPeer(pubKeyStr)
 				}
 			}
@@ -410,6 +425,56 @@ func (s *server) updatePersistentPeerAddrs() error {
 	return nil
 }
 
+// setPersistentPeerAddrs replaces the stored addresses of the given persistent
+// peer, returning true if the set of addresses changed.
+//
+// NOTE: The server's mutex MUST be held when calling this function.
+func (s *server) setPersistentPeerAddrs(pubKeyStr string,
+	addrs []*lnwire.NetAddress) bool {
+
+	changed := !sameNetAddrs(s.persistentPeerAddrs[pubKeyStr], addrs)
+	s.persistentPeerAddrs[pubKeyStr] = addrs
+
+	return changed
+}
+
+// notifyPeerAddrsUpdated lets peer event subscribers know about the new set of
+// addresses of the given persistent peer.
+func (s *server) notifyPeerAddrsUpdated(pubKeyStr string, addrs []net.Addr) {
+	event := peernotifier.PeerAddressUpdatedEvent{
+		Addresses: addrs,
+	}
+	copy(event.PubKey[:], pubKeyStr)
+
+	s.peerNotifier.NotifyPeerAddressUpdated(event)
+}
+
+// sameNetAddrs returns true if the two lists contain the same set of network
+// addresses, regardless of their order.
+func sameNetAddrs(a, b []*lnwire.NetAddress) bool {
+	setA := make(map[string]struct{}, len(a))
+	for _, addr := range a {
+		setA[addr.Address.String()] = struct{}{}
+	}
+
+	setB := make(map[string]struct{}, len(b))
+	for _, addr := range b {
+		setB[addr.Address.String()] = struct{}{}
+	}
+
+	if len(setA) != len(setB) {
+		return false
+	}
+
+	for addr := range setA {
+		if _, ok := setB[addr]; !ok {
+			return false
+		}
+	}
+
+	return true
+}
+
 // CustomMessage is a custom message that is received from a peer.
 type CustomMessage struct {
 	// Peer is the peer pubkey
@@ -3801,7 +3866,11 @@ func (s *server) addPeer(p *peer.Brontide) {
 	var pubKey [33]byte
 	copy(pubKey[:], pubSer)
 
-	s.peerNotifier.NotifyPeerOnline(pubKey)
+	s.peerNotifier.NotifyPeerOnline(peernotifier.PeerOnlineEvent{
+		PubKey:  pubKey,
+		Inbound: p.Inbound(),
+		Address: p.Address(),
+	})
 }
 
 // peerInitializer asynchronously starts a newly connected peer after it has
@@ -4028,6 +4097,15 @@ func (s *server) peerTerminationWatcher(p *peer.Brontide, ready chan struct{}) {
 	backoff := s.nextPeerBackoff(pubStr, p.StartTime())
 	s.persistentPeersBackoff[pubStr] = backoff
 
+	// Let subscribers know when we'll be attempting to reconnect, so that
+	// external connection managers don't race our own attempt.
+	s.peerNotifier.NotifyPeerReconnectScheduled(
+		peernotifier.PeerReconnectScheduledEvent{
+			PubKey:  p.PubKey(),
+			Backoff: backoff,
+		},
+	)
+
 	// Initialize a retry canceller for this peer if one does not
 	// exist.
 	cancelChan, ok := s.persistentRetryCancels[pubStr]
@@ -4203,7 +4281,16 @@ func (s *server) removePeer(p *peer.Brontide) {
 	var pubKey [33]byte
 	copy(pubKey[:], pubSer)
 
-	s.peerNotifier.NotifyPeerOffline(pubKey)
+	// The peer may have been disconnected while it was still starting
+	// up, so we use AdvertisedFeatures which is safe to read concurrently
+	// with Start.
+	s.peerNotifier.NotifyPeerOffline(peernotifier.PeerOfflineEvent{
+		PubKey:   pubKey,
+		Inbound:  p.Inbound(),
+		Address:  p.Address(),
+		Features: p.AdvertisedFeatures(),
+		Reason:   p.DisconnectReason(),
+	})
 }
 
 // ConnectToPeer requests that the server connect to a Lightning Network peer
@@ -19,6 +19,7 @@ import (
 	"time"
 
 	"github.com/lightningnetwork/lnd/lncfg"
+	"github.com/lightningnetwork/lnd/lnwire"
 	"github.com/stretchr/testify/require"
 )
 
@@ -229,3 +230,101 @@ func TestShouldPeerBootstrap(t *testing.T) {
 		}
 	}
 }
+
+// TestSameNetAddrs tests that two lists of network addresses are only
+// considered equal if they contain the same set of addresses.
+func TestSameNetAddrs(t *testing.T) {
+	t.Parallel()
+
+	netAddr := func(addr string) *lnwire.NetAddress {
+		tcpAddr, err := net.ResolveTCPAddr("tcp", addr)
+		require.NoError(t, err)
+
+		return &lnwire.NetAddress{Address: tcpAddr}
+	}
+
+	addr1 := netAddr("10.0.0.1:9735")
+	addr2 := netAddr("10.0.0.2:9735")
+	addr3 := netAddr("10.0.0.3:9735")
+
+	testCases := []struct {
+		name string
+		a, b []*lnwire.NetAddress
+		same bool
+	}{
+		{
+			name: "both empty",
+			same: true,
+		},
+		{
+			name: "new addresses",
+			b:    []*lnwire.NetAddress{addr1},
+			same: false,
+		},
+		{
+			name: "same order",
+			a:    []*lnwire.NetAddress{addr1, addr2},
+			b:    []*lnwire.NetAddress{addr1, addr2},
+			same: true,
+		},
+		{
+			name: "different order",
+			a:    []*lnwire.NetAddress{addr1, addr2},
+			b:    []*lnwire.NetAddress{addr2, addr1},
+			same: true,
+		},
+		{
+			name: "duplicate address",
+			a:    []*lnwire.NetAddress{addr1, addr1},
+			b:    []*lnwire.NetAddress{addr1},
+			same: true,
+		},
+		{
+			name: "address replaced",
+			a:    []*lnwire.NetAddress{addr1, addr2},
+			b:    []*lnwire.NetAddress{addr1, addr3},
+			same: false,
+		},
+		{
+			name: "address removed",
+			a:    []*lnwire.NetAddress{addr1, addr2},
+			b:    []*lnwire.NetAddress{addr1},
+			same: false,
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			same := sameNetAddrs(testCase.a, testCase.b)
+			require.Equal(t, testCase.same, same)
+		})
+	}
+}
+
+// TestSetPersistentPeerAddrs tests that replacing the stored addresses of a
+// persistent peer reports whether the set of addresses changed.
+func TestSetPersistentPeerAddrs(t *testing.T) {
+	t.Parallel()
+
+	tcpAddr, err := net.ResolveTCPAddr("tcp", "10.0.0.1:9735")
+	require.NoError(t, err)
+	addrs := []*lnwire.NetAddress{{Address: tcpAddr}}
+
+	s := &server{
+		persistentPeerAddrs: make(map[string][]*lnwire.NetAddress),
+	}
+
+	// Storing addresses for the first time is a change, storing the same
+	// set again isn't.
+	require.True(t, s.setPersistentPeerAddrs("peer", addrs))
+	require.False(t, s.setPersistentPeerAddrs("peer", addrs))
+	require.Equal(t, addrs, s.persistentPeerAddrs["peer"])
+
+	// Removing all addresses is a change again.
+	require.True(t, s.setPersistentPeerAddrs("peer", nil))
+	require.Empty(t, s.persistentPeerAddrs["peer"])
+}